@@ -0,0 +1,271 @@
+package promise
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status describes which way a promise settled, as reported by AllSettled.
+type Status string
+
+const (
+	// Fulfilled marks a Result whose Value came from a promise that resolved.
+	Fulfilled Status = "fulfilled"
+
+	// Rejected marks a Result whose Err came from a promise that rejected.
+	Rejected Status = "rejected"
+)
+
+// Result captures the outcome of a single promise settled by AllSettled.
+type Result struct {
+	Value interface{}
+	Err   error
+	State Status
+}
+
+// AggregateError collects the rejection reasons of every promise passed to
+// Any when none of them fulfill.
+type AggregateError struct {
+	Errors []error
+}
+
+func (e *AggregateError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return "all promises were rejected: " + strings.Join(messages, "; ")
+}
+
+// All returns a *Promise that resolves to a []interface{} of the results of
+// promises, in the same order they were given, once every one of them has
+// fulfilled. It rejects as soon as any one of them rejects.
+func All(promises ...*Promise) *Promise {
+	return New(func(resolve func(interface{}), reject func(error)) {
+		var count = len(promises)
+		if count == 0 {
+			resolve([]interface{}{})
+			return
+		}
+
+		var (
+			mutex     sync.Mutex
+			results   = make([]interface{}, count)
+			remaining = count
+		)
+
+		for i, promise := range promises {
+			i := i
+			promise.Then(func(data interface{}) interface{} {
+				mutex.Lock()
+				results[i] = data
+				remaining--
+				done := remaining == 0
+				mutex.Unlock()
+
+				if done {
+					resolve(results)
+				}
+				return nil
+			}).Catch(func(err error) error {
+				reject(err)
+				return err
+			})
+		}
+	})
+}
+
+// Race returns a *Promise that settles the same way as whichever of
+// promises settles first.
+func Race(promises ...*Promise) *Promise {
+	return New(func(resolve func(interface{}), reject func(error)) {
+		for _, promise := range promises {
+			promise.Then(func(data interface{}) interface{} {
+				resolve(data)
+				return nil
+			}).Catch(func(err error) error {
+				reject(err)
+				return err
+			})
+		}
+	})
+}
+
+// Any returns a *Promise that resolves with the value of the first of
+// promises to fulfill. If every one of them rejects, it rejects with an
+// *AggregateError holding all of their reasons.
+func Any(promises ...*Promise) *Promise {
+	return New(func(resolve func(interface{}), reject func(error)) {
+		var count = len(promises)
+		if count == 0 {
+			reject(&AggregateError{})
+			return
+		}
+
+		var (
+			mutex     sync.Mutex
+			errs      = make([]error, count)
+			remaining = count
+		)
+
+		for i, promise := range promises {
+			i := i
+			promise.Then(func(data interface{}) interface{} {
+				resolve(data)
+				return nil
+			}).Catch(func(err error) error {
+				mutex.Lock()
+				errs[i] = err
+				remaining--
+				done := remaining == 0
+				mutex.Unlock()
+
+				if done {
+					reject(&AggregateError{Errors: errs})
+				}
+				return err
+			})
+		}
+	})
+}
+
+// AllSettled returns a *Promise that always fulfills, once every one of
+// promises has settled, with a []Result describing each outcome in order.
+func AllSettled(promises ...*Promise) *Promise {
+	return New(func(resolve func(interface{}), reject func(error)) {
+		var count = len(promises)
+		if count == 0 {
+			resolve([]Result{})
+			return
+		}
+
+		var (
+			mutex     sync.Mutex
+			results   = make([]Result, count)
+			remaining = count
+		)
+
+		settle := func(i int, result Result) {
+			mutex.Lock()
+			results[i] = result
+			remaining--
+			done := remaining == 0
+			mutex.Unlock()
+
+			if done {
+				resolve(results)
+			}
+		}
+
+		for i, promise := range promises {
+			i := i
+			promise.Then(func(data interface{}) interface{} {
+				settle(i, Result{Value: data, State: Fulfilled})
+				return nil
+			}).Catch(func(err error) error {
+				settle(i, Result{Err: err, State: Rejected})
+				return err
+			})
+		}
+	})
+}
+
+// Map runs fn over items with at most concurrency calls in flight at once
+// and returns a *Promise that resolves to the ordered slice of results, or
+// rejects as soon as any call's promise rejects. A concurrency of 0 or
+// greater than len(items) runs every item at once.
+func Map(items []interface{}, concurrency int, fn func(interface{}) *Promise) *Promise {
+	return New(func(resolve func(interface{}), reject func(error)) {
+		var count = len(items)
+		if count == 0 {
+			resolve([]interface{}{})
+			return
+		}
+		if concurrency <= 0 || concurrency > count {
+			concurrency = count
+		}
+
+		var (
+			mutex     sync.Mutex
+			results   = make([]interface{}, count)
+			remaining = count
+			failed    bool
+			indices   = make(chan int, count)
+			workers   sync.WaitGroup
+		)
+
+		for i := 0; i < count; i++ {
+			indices <- i
+		}
+		close(indices)
+
+		for w := 0; w < concurrency; w++ {
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+
+				for i := range indices {
+					mutex.Lock()
+					if failed {
+						mutex.Unlock()
+						return
+					}
+					mutex.Unlock()
+
+					value, err := fn(items[i]).Await()
+
+					mutex.Lock()
+					if failed {
+						mutex.Unlock()
+						continue
+					}
+					if err != nil {
+						failed = true
+						mutex.Unlock()
+						reject(err)
+						return
+					}
+					results[i] = value
+					remaining--
+					done := remaining == 0
+					mutex.Unlock()
+
+					if done {
+						resolve(results)
+					}
+				}
+			}()
+		}
+
+		workers.Wait()
+	})
+}
+
+// Retry calls fn and, if the promise it returns rejects, calls it again -
+// sleeping for backoff(attempt) in between - up to attempts times in total.
+// It resolves with the first fulfilled value, or rejects with the last
+// rejection reason once attempts is exhausted.
+func Retry(attempts int, backoff func(attempt int) time.Duration, fn func() *Promise) *Promise {
+	return New(func(resolve func(interface{}), reject func(error)) {
+		if attempts < 1 {
+			attempts = 1
+		}
+
+		var lastErr error
+		for attempt := 1; attempt <= attempts; attempt++ {
+			value, err := fn().Await()
+			if err == nil {
+				resolve(value)
+				return
+			}
+
+			lastErr = err
+			if attempt < attempts && backoff != nil {
+				time.Sleep(backoff(attempt))
+			}
+		}
+
+		reject(lastErr)
+	})
+}
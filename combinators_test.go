@@ -0,0 +1,170 @@
+package promise
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAll(t *testing.T) {
+	var a = New(func(resolve func(interface{}), reject func(error)) {
+		resolve(1)
+	})
+	var b = New(func(resolve func(interface{}), reject func(error)) {
+		resolve(2)
+	})
+
+	All(a, b).Then(func(data interface{}) interface{} {
+		results := data.([]interface{})
+		if results[0].(int) != 1 || results[1].(int) != 2 {
+			t.Fatal("RESULTS SHOULD BE IN ORDER")
+		}
+		return nil
+	}).Catch(func(err error) error {
+		t.Fatal("ALL SHOULD NOT REJECT")
+		return err
+	}).Await()
+}
+
+func TestAll_Rejects(t *testing.T) {
+	returnedError := errors.New("ERROR!")
+	var a = New(func(resolve func(interface{}), reject func(error)) {
+		resolve(1)
+	})
+	var b = New(func(resolve func(interface{}), reject func(error)) {
+		reject(returnedError)
+	})
+
+	All(a, b).Then(func(data interface{}) interface{} {
+		t.Fatal("ALL SHOULD REJECT")
+		return nil
+	}).Catch(func(err error) error {
+		if err != returnedError {
+			t.Fatal("SHOULD GET REJECTION REASON FROM FAILING PROMISE")
+		}
+		return err
+	}).Await()
+}
+
+func TestRace(t *testing.T) {
+	var slow = New(func(resolve func(interface{}), reject func(error)) {
+		time.Sleep(50 * time.Millisecond)
+		resolve("slow")
+	})
+	var fast = New(func(resolve func(interface{}), reject func(error)) {
+		resolve("fast")
+	})
+
+	Race(slow, fast).Then(func(data interface{}) interface{} {
+		if data.(string) != "fast" {
+			t.Fatal("RACE SHOULD SETTLE WITH THE FIRST PROMISE")
+		}
+		return nil
+	}).Await()
+}
+
+func TestAny(t *testing.T) {
+	var failing = New(func(resolve func(interface{}), reject func(error)) {
+		reject(errors.New("nope"))
+	})
+	var succeeding = New(func(resolve func(interface{}), reject func(error)) {
+		resolve("yep")
+	})
+
+	Any(failing, succeeding).Then(func(data interface{}) interface{} {
+		if data.(string) != "yep" {
+			t.Fatal("ANY SHOULD RESOLVE WITH THE FULFILLED PROMISE")
+		}
+		return nil
+	}).Catch(func(err error) error {
+		t.Fatal("ANY SHOULD NOT REJECT WHEN ONE PROMISE FULFILLS")
+		return err
+	}).Await()
+}
+
+func TestAny_AllRejected(t *testing.T) {
+	var a = New(func(resolve func(interface{}), reject func(error)) {
+		reject(errors.New("a failed"))
+	})
+	var b = New(func(resolve func(interface{}), reject func(error)) {
+		reject(errors.New("b failed"))
+	})
+
+	Any(a, b).Catch(func(err error) error {
+		aggregate, ok := err.(*AggregateError)
+		if !ok || len(aggregate.Errors) != 2 {
+			t.Fatal("SHOULD REJECT WITH AN AGGREGATE ERROR OF BOTH REASONS")
+		}
+		return err
+	}).Await()
+}
+
+func TestAllSettled(t *testing.T) {
+	returnedError := errors.New("ERROR!")
+	var a = New(func(resolve func(interface{}), reject func(error)) {
+		resolve(1)
+	})
+	var b = New(func(resolve func(interface{}), reject func(error)) {
+		reject(returnedError)
+	})
+
+	AllSettled(a, b).Then(func(data interface{}) interface{} {
+		results := data.([]Result)
+		if results[0].State != Fulfilled || results[0].Value.(int) != 1 {
+			t.Fatal("FIRST RESULT SHOULD BE FULFILLED WITH 1")
+		}
+		if results[1].State != Rejected || results[1].Err != returnedError {
+			t.Fatal("SECOND RESULT SHOULD BE REJECTED WITH THE ORIGINAL ERROR")
+		}
+		return nil
+	}).Await()
+}
+
+func TestMap(t *testing.T) {
+	items := []interface{}{1, 2, 3, 4, 5}
+
+	Map(items, 2, func(item interface{}) *Promise {
+		return New(func(resolve func(interface{}), reject func(error)) {
+			resolve(item.(int) * 2)
+		})
+	}).Then(func(data interface{}) interface{} {
+		results := data.([]interface{})
+		for i, item := range items {
+			if results[i].(int) != item.(int)*2 {
+				t.Fatal("RESULTS SHOULD BE DOUBLED IN ORIGINAL ORDER")
+			}
+		}
+		return nil
+	}).Catch(func(err error) error {
+		t.Fatal("MAP SHOULD NOT REJECT")
+		return err
+	}).Await()
+}
+
+func TestRetry(t *testing.T) {
+	var attemptsMade int
+
+	Retry(3, func(attempt int) time.Duration {
+		return time.Millisecond
+	}, func() *Promise {
+		return New(func(resolve func(interface{}), reject func(error)) {
+			attemptsMade++
+			if attemptsMade < 3 {
+				reject(errors.New("not yet"))
+				return
+			}
+			resolve("done")
+		})
+	}).Then(func(data interface{}) interface{} {
+		if data.(string) != "done" {
+			t.Fatal("RETRY SHOULD RESOLVE WITH THE EVENTUALLY SUCCESSFUL VALUE")
+		}
+		if attemptsMade != 3 {
+			t.Fatal("RETRY SHOULD STOP AS SOON AS A CALL SUCCEEDS")
+		}
+		return nil
+	}).Catch(func(err error) error {
+		t.Fatal("RETRY SHOULD EVENTUALLY SUCCEED")
+		return err
+	}).Await()
+}
@@ -0,0 +1,102 @@
+package promise
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrCanceled is the rejection reason given to a promise created with
+	// NewWithContext whose context is canceled before it settles.
+	ErrCanceled = errors.New("promise: canceled")
+
+	// ErrTimeout is the rejection reason given to a promise created with
+	// NewWithContext whose context deadline is exceeded, or to one derived
+	// from WithTimeout that doesn't settle within the given duration.
+	ErrTimeout = errors.New("promise: timed out")
+)
+
+// NewWithContext instantiates and returns a *Promise bound to ctx. executor
+// is called exactly like the one passed to New, except it additionally
+// receives ctx so it can abort in-flight work (an HTTP call, a DB query) as
+// soon as ctx is canceled. If ctx is canceled or its deadline is exceeded
+// before the executor settles the promise itself, the promise rejects with
+// ErrCanceled or ErrTimeout respectively, and downstream Then handlers are
+// skipped.
+func NewWithContext(ctx context.Context, executor func(ctx context.Context, resolve func(interface{}), reject func(error))) *Promise {
+	ctx, cancel := context.WithCancel(ctx)
+
+	var promise = newPendingPromise()
+	promise.cancel = cancel
+	promise.executor = func(resolve func(interface{}), reject func(error)) {
+		executor(ctx, resolve, reject)
+	}
+
+	go func() {
+		defer promise.handlePanic()
+		promise.executor(promise.resolve, promise.reject)
+	}()
+
+	go func() {
+		<-ctx.Done()
+		if ctx.Err() == context.DeadlineExceeded {
+			promise.reject(ErrTimeout)
+		} else {
+			promise.reject(ErrCanceled)
+		}
+	}()
+
+	return promise
+}
+
+// Cancel aborts the context a promise created with NewWithContext was
+// bound to, rejecting it with ErrCanceled if it hasn't already settled. It
+// is a no-op on promises not created with NewWithContext.
+func (promise *Promise) Cancel() {
+	if promise.cancel != nil {
+		promise.cancel()
+	}
+}
+
+// WithTimeout returns a brand-new *Promise that settles the same way the
+// receiver does, unless d elapses first, in which case it rejects with
+// ErrTimeout instead. The receiver itself keeps running either way - wrap
+// a NewWithContext promise if the underlying work should also be aborted.
+func (promise *Promise) WithTimeout(d time.Duration) *Promise {
+	return New(func(resolve func(interface{}), reject func(error)) {
+		var timer = time.NewTimer(d)
+		var settled = make(chan struct{})
+
+		promise.Then(func(data interface{}) interface{} {
+			timer.Stop()
+			close(settled)
+			resolve(data)
+			return nil
+		}).Catch(func(err error) error {
+			timer.Stop()
+			close(settled)
+			reject(err)
+			return err
+		})
+
+		go func() {
+			select {
+			case <-timer.C:
+				// The receiver may have settled in the instant between the
+				// timer firing and Stop() running, racing to close settled
+				// against this goroutine waking up. Let that win: resolve/
+				// reject are idempotent, but checking first avoids reporting
+				// a timeout once settled has been closed. A receiver that
+				// settles before d but whose reaction has not closed settled
+				// by the time this check runs is still reported as timed out.
+				select {
+				case <-settled:
+				default:
+					reject(ErrTimeout)
+				}
+			case <-settled:
+			}
+		}()
+	})
+}
@@ -0,0 +1,112 @@
+package promise
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestNewWithContext_Resolves(t *testing.T) {
+	var promise = NewWithContext(context.Background(), func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+		resolve(42)
+	})
+
+	promise.Then(func(data interface{}) interface{} {
+		if data.(int) != 42 {
+			t.Fatal("SHOULD RESOLVE WITH THE EXECUTOR'S VALUE")
+		}
+		return nil
+	}).Await()
+}
+
+func TestNewWithContext_Cancel(t *testing.T) {
+	var promise = NewWithContext(context.Background(), func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+		// Simulates aborting in-flight work once ctx is canceled; the
+		// rejection itself is supplied by NewWithContext's own watcher.
+		<-ctx.Done()
+	})
+
+	promise.Catch(func(err error) error {
+		if err != ErrCanceled {
+			t.Fatal("CANCELED PROMISE SHOULD REJECT WITH ErrCanceled")
+		}
+		return err
+	})
+
+	promise.Cancel()
+	promise.Await()
+}
+
+// TestNewWithContext_DoesNotLeakWatcherGoroutines guards against the
+// ctx.Done() watcher sticking around forever once a promise settles on its
+// own - e.g. under context.Background(), whose Done() channel never fires.
+func TestNewWithContext_DoesNotLeakWatcherGoroutines(t *testing.T) {
+	runtime.GC()
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 200; i++ {
+		var promise = NewWithContext(context.Background(), func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+			resolve(i)
+		})
+		promise.Await()
+	}
+
+	// Give the settled promises' watcher goroutines a moment to observe
+	// the now-canceled context and exit.
+	time.Sleep(50 * time.Millisecond)
+	runtime.GC()
+	after := runtime.NumGoroutine()
+
+	if after > before+10 {
+		t.Fatalf("GOROUTINE COUNT GREW FROM %d TO %d, WATCHER GOROUTINES ARE LEAKING", before, after)
+	}
+}
+
+func TestPromise_WithTimeout_Resolves(t *testing.T) {
+	var promise = New(func(resolve func(interface{}), reject func(error)) {
+		resolve("fast")
+	})
+
+	promise.WithTimeout(50 * time.Millisecond).Then(func(data interface{}) interface{} {
+		if data.(string) != "fast" {
+			t.Fatal("SHOULD RESOLVE WITH THE ORIGINAL VALUE WHEN FASTER THAN THE TIMEOUT")
+		}
+		return nil
+	}).Await()
+}
+
+// TestPromise_WithTimeout_DoesNotLeakTimerGoroutines guards against the
+// timeout watcher outliving a receiver that settles well before d elapses.
+func TestPromise_WithTimeout_DoesNotLeakTimerGoroutines(t *testing.T) {
+	runtime.GC()
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 200; i++ {
+		var promise = New(func(resolve func(interface{}), reject func(error)) {
+			resolve(i)
+		})
+		promise.WithTimeout(time.Hour).Await()
+	}
+
+	runtime.GC()
+	after := runtime.NumGoroutine()
+
+	if after > before+10 {
+		t.Fatalf("GOROUTINE COUNT GREW FROM %d TO %d, TIMEOUT WATCHERS ARE LEAKING", before, after)
+	}
+}
+
+func TestPromise_WithTimeout_TimesOut(t *testing.T) {
+	var promise = New(func(resolve func(interface{}), reject func(error)) {
+		time.Sleep(100 * time.Millisecond)
+		resolve("slow")
+	})
+
+	promise.WithTimeout(10 * time.Millisecond).Catch(func(err error) error {
+		if err != ErrTimeout {
+			t.Fatal("SHOULD REJECT WITH ErrTimeout WHEN SLOWER THAN THE TIMEOUT")
+		}
+		return err
+	}).Await()
+}
@@ -0,0 +1,122 @@
+// Package generic provides a type-safe Promise API built with Go generics
+// on top of the promise package's interface{}-based *promise.Promise, so
+// callers no longer have to type-assert (data.(int)) on every handler.
+package generic
+
+import "github.com/ceoro9/promise"
+
+// A G[T] is a *promise.Promise known to settle with a value of type T.
+type G[T any] struct {
+	inner *promise.Promise
+}
+
+// New instantiates and returns a *G[T], running executor exactly like
+// promise.New does.
+func New[T any](executor func(resolve func(T), reject func(error))) *G[T] {
+	return &G[T]{
+		inner: promise.New(func(resolve func(interface{}), reject func(error)) {
+			executor(func(value T) { resolve(value) }, reject)
+		}),
+	}
+}
+
+// Then registers fn and returns a brand-new *G[U] that fulfills with fn's
+// returned value, or rejects with the error fn returns (or that it panics
+// with). If g rejects, fn is skipped and the reason propagates unchanged.
+func Then[T, U any](g *G[T], fn func(T) (U, error)) *G[U] {
+	return &G[U]{
+		inner: promise.New(func(resolve func(interface{}), reject func(error)) {
+			value, err := g.inner.Await()
+			if err != nil {
+				reject(err)
+				return
+			}
+
+			result, err := fn(value.(T))
+			if err != nil {
+				reject(err)
+				return
+			}
+			resolve(result)
+		}),
+	}
+}
+
+// Catch registers fn and returns a brand-new *G[T] that resolves with fn's
+// recovered value, or rejects with the error fn returns. If g fulfills, fn
+// is skipped and its value propagates unchanged.
+func Catch[T any](g *G[T], fn func(error) (T, error)) *G[T] {
+	return &G[T]{
+		inner: promise.New(func(resolve func(interface{}), reject func(error)) {
+			value, err := g.inner.Await()
+			if err == nil {
+				resolve(value)
+				return
+			}
+
+			recovered, handledErr := fn(err)
+			if handledErr != nil {
+				reject(handledErr)
+				return
+			}
+			resolve(recovered)
+		}),
+	}
+}
+
+// Await blocks until g settles and returns its value, or the zero value of
+// T and the rejection reason if it rejected.
+func (g *G[T]) Await() (T, error) {
+	value, err := g.inner.Await()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value.(T), nil
+}
+
+// All returns a *G[[]T] that resolves to the results of promises, in
+// order, once every one of them has fulfilled, or rejects as soon as any
+// one of them rejects.
+func All[T any](promises ...*G[T]) *G[[]T] {
+	return &G[[]T]{
+		inner: promise.New(func(resolve func(interface{}), reject func(error)) {
+			value, err := promise.All(innerOf(promises)...).Await()
+			if err != nil {
+				reject(err)
+				return
+			}
+
+			raw := value.([]interface{})
+			typed := make([]T, len(raw))
+			for i, v := range raw {
+				typed[i] = v.(T)
+			}
+			resolve(typed)
+		}),
+	}
+}
+
+// Race returns a *G[T] that settles the same way as whichever of promises
+// settles first.
+func Race[T any](promises ...*G[T]) *G[T] {
+	return &G[T]{
+		inner: promise.New(func(resolve func(interface{}), reject func(error)) {
+			value, err := promise.Race(innerOf(promises)...).Await()
+			if err != nil {
+				reject(err)
+				return
+			}
+			resolve(value.(T))
+		}),
+	}
+}
+
+func innerOf[T any](promises []*G[T]) []*promise.Promise {
+	inner := make([]*promise.Promise, len(promises))
+	for i, p := range promises {
+		inner[i] = p.inner
+	}
+	return inner
+}
+
@@ -0,0 +1,111 @@
+package generic
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNew_Await(t *testing.T) {
+	p := New(func(resolve func(int), reject func(error)) {
+		resolve(42)
+	})
+
+	value, err := p.Await()
+	if err != nil {
+		t.Fatal("DID NOT EXPECT AN ERROR")
+	}
+	if value != 42 {
+		t.Fatal("VALUE SHOULD BE THE RESOLVED INT, NO TYPE ASSERTION NEEDED")
+	}
+}
+
+func TestThen(t *testing.T) {
+	p := New(func(resolve func(int), reject func(error)) {
+		resolve(1)
+	})
+
+	q := Then(p, func(value int) (string, error) {
+		if value != 1 {
+			t.Fatal("HANDLER SHOULD RECEIVE THE PARENT'S VALUE")
+		}
+		return "one", nil
+	})
+
+	value, err := q.Await()
+	if err != nil {
+		t.Fatal("DID NOT EXPECT AN ERROR")
+	}
+	if value != "one" {
+		t.Fatal("VALUE SHOULD BE WHATEVER THEN'S HANDLER RETURNED")
+	}
+}
+
+func TestThen_PropagatesError(t *testing.T) {
+	returnedError := errors.New("ERROR!")
+	p := New(func(resolve func(int), reject func(error)) {
+		resolve(1)
+	})
+
+	q := Then(p, func(value int) (string, error) {
+		return "", returnedError
+	})
+
+	_, err := q.Await()
+	if err != returnedError {
+		t.Fatal("SHOULD REJECT WITH THE ERROR RETURNED BY THEN'S HANDLER")
+	}
+}
+
+func TestCatch(t *testing.T) {
+	returnedError := errors.New("ERROR!")
+	p := New(func(resolve func(int), reject func(error)) {
+		reject(returnedError)
+	})
+
+	q := Catch(p, func(err error) (int, error) {
+		if err != returnedError {
+			t.Fatal("HANDLER SHOULD RECEIVE THE PARENT'S REJECTION REASON")
+		}
+		return -1, nil
+	})
+
+	value, err := q.Await()
+	if err != nil {
+		t.Fatal("RECOVERING WITH A NIL ERROR SHOULD FULFILL")
+	}
+	if value != -1 {
+		t.Fatal("VALUE SHOULD BE WHATEVER CATCH'S HANDLER RECOVERED WITH")
+	}
+}
+
+func TestAll(t *testing.T) {
+	a := New(func(resolve func(int), reject func(error)) { resolve(1) })
+	b := New(func(resolve func(int), reject func(error)) { resolve(2) })
+
+	values, err := All(a, b).Await()
+	if err != nil {
+		t.Fatal("DID NOT EXPECT AN ERROR")
+	}
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Fatal("RESULTS SHOULD BE IN ORDER")
+	}
+}
+
+func TestRace(t *testing.T) {
+	slow := New(func(resolve func(string), reject func(error)) {
+		time.Sleep(50 * time.Millisecond)
+		resolve("slow")
+	})
+	fast := New(func(resolve func(string), reject func(error)) {
+		resolve("fast")
+	})
+
+	value, err := Race(fast, slow).Await()
+	if err != nil {
+		t.Fatal("DID NOT EXPECT AN ERROR")
+	}
+	if value != "fast" {
+		t.Fatal("SHOULD SETTLE WITH THE FIRST PROMISE")
+	}
+}
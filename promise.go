@@ -1,7 +1,8 @@
 package promise
 
 import (
-	"errors"
+	"context"
+	"fmt"
 	"sync"
 )
 
@@ -35,13 +36,10 @@ type Promise struct {
 	// an error or panic occurred.
 	executor func(resolve func(interface{}), reject func(error))
 
-	// Appends fulfillment to the promise,
-	// and returns a new promise.
-	then []func(data interface{}) interface{}
-
-	// Appends a rejection handler to the promise,
-	// and returns a new promise.
-	catch []func(error error) error
+	// Reactions registered via Then/Catch while the promise is still
+	// pending. Each reaction belongs to exactly one promise derived from
+	// this one, and fires (then is discarded) the moment this one settles.
+	reactions []reaction
 
 	// Stores the result passed to resolve()
 	result interface{}
@@ -52,22 +50,31 @@ type Promise struct {
 	// Mutex protects against data race conditions.
 	mutex *sync.Mutex
 
-	// WaitGroup allows to block until all callbacks are executed.
-	wg *sync.WaitGroup
+	// done is closed exactly once, by resolve or reject, once the promise
+	// has settled and every reaction registered on it has run. Await blocks
+	// on it to report the actual outcome, rather than racing the settlement
+	// against whichever goroutine happens to run those reactions.
+	done chan struct{}
+
+	// cancel aborts the context a promise created with NewWithContext was
+	// bound to. It is nil for promises that weren't. resolve/reject call it
+	// once the promise settles so the context-cancellation watcher that
+	// NewWithContext starts doesn't leak for the life of the program.
+	cancel context.CancelFunc
+}
+
+// A reaction pairs the fulfillment and rejection callbacks registered
+// together for a single derived promise, so that whichever outcome
+// actually happens is routed to the right one.
+type reaction struct {
+	onFulfilled func(data interface{})
+	onRejected  func(error error)
 }
 
 // New instantiates and returns a *Promise object.
 func New(executor func(resolve func(interface{}), reject func(error))) *Promise {
-	var promise = &Promise{
-		state:    pending,
-		executor: executor,
-		then:     make([]func(interface{}) interface{}, 0),
-		catch:    make([]func(error) error, 0),
-		result:   nil,
-		error:    nil,
-		mutex:    &sync.Mutex{},
-		wg:       &sync.WaitGroup{},
-	}
+	var promise = newPendingPromise()
+	promise.executor = executor
 
 	go func() {
 		defer promise.handlePanic()
@@ -77,121 +84,231 @@ func New(executor func(resolve func(interface{}), reject func(error))) *Promise
 	return promise
 }
 
+// newPendingPromise builds a fresh, unsettled *Promise with no executor of
+// its own. It backs both New and every promise derived from Then/Catch.
+func newPendingPromise() *Promise {
+	return &Promise{
+		state:     pending,
+		reactions: make([]reaction, 0),
+		mutex:     &sync.Mutex{},
+		done:      make(chan struct{}),
+	}
+}
+
 func (promise *Promise) resolve(resolution interface{}) {
 	promise.mutex.Lock()
-	defer promise.mutex.Unlock()
 
 	if promise.state != pending {
+		promise.mutex.Unlock()
 		return
 	}
 
 	promise.state = fulfilled
 	promise.result = resolution
-	doneCounter := 0
-
-	for _, value := range promise.then {
-		promise.result = value(promise.result)
-		// check if returned value is promise
-		if thenPromise, ok := promise.result.(*Promise); ok {
-			isRejected := false
-
-			thenPromise.Then(func(result interface{}) interface{} {
-				promise.result = result
-				return nil
-			}).Catch(func(err error) error {
-				chainError := err
-				isRejected = true
-
-				for i := 0; i < len(promise.then)-doneCounter; i++ {
-					promise.wg.Done()
-				}
-				for _, value := range promise.catch {
-					chainError = value(chainError)
-					promise.wg.Done()
-				}
-				return chainError
-			}).Await()
-
-			if isRejected {
-				return
-			}
-		}
-		promise.wg.Done()
-		doneCounter++
+	reactions := promise.reactions
+	promise.reactions = nil
+	promise.mutex.Unlock()
+
+	if promise.cancel != nil {
+		promise.cancel()
 	}
 
-	for range promise.catch {
-		promise.wg.Done()
+	for _, r := range reactions {
+		r.onFulfilled(resolution)
 	}
+	close(promise.done)
 }
 
 func (promise *Promise) reject(error error) {
 	promise.mutex.Lock()
-	defer promise.mutex.Unlock()
 
 	if promise.state != pending {
+		promise.mutex.Unlock()
 		return
 	}
 
-	for range promise.then {
-		promise.wg.Done()
-	}
-
+	promise.state = rejected
 	promise.error = error
+	reactions := promise.reactions
+	promise.reactions = nil
+	promise.mutex.Unlock()
 
-	for _, value := range promise.catch {
-		promise.error = value(promise.error)
-		promise.wg.Done()
+	if promise.cancel != nil {
+		promise.cancel()
 	}
 
-	promise.state = rejected
+	for _, r := range reactions {
+		r.onRejected(error)
+	}
+	close(promise.done)
 }
 
 func (promise *Promise) handlePanic() {
-	var r = recover()
-	if r != nil {
-		promise.reject(errors.New(r.(string)))
+	if r := recover(); r != nil {
+		promise.reject(panicToError(r))
 	}
 }
 
-// Then appends fulfillment handler to the promise, and returns a new promise.
-func (promise *Promise) Then(fulfillment func(data interface{}) interface{}) *Promise {
+func panicToError(r interface{}) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", r)
+}
+
+// addReaction registers onFulfilled/onRejected to run once the promise
+// settles, or dispatches to the matching one immediately if it already has.
+// Every caller - whether it's Then or Catch - owns exactly one derived
+// promise, so exactly one of the two callbacks will ever run.
+func (promise *Promise) addReaction(onFulfilled func(interface{}), onRejected func(error)) {
 	promise.mutex.Lock()
-	defer promise.mutex.Unlock()
 
-	if promise.state == pending {
-		promise.wg.Add(1)
-		promise.then = append(promise.then, fulfillment)
-	} else if promise.state == fulfilled {
-		promise.result = fulfillment(promise.result)
+	switch promise.state {
+	case pending:
+		promise.reactions = append(promise.reactions, reaction{onFulfilled, onRejected})
+		promise.mutex.Unlock()
+	case fulfilled:
+		result := promise.result
+		promise.mutex.Unlock()
+		onFulfilled(result)
+	case rejected:
+		err := promise.error
+		promise.mutex.Unlock()
+		onRejected(err)
 	}
+}
 
-	return promise
+// Then registers a fulfillment handler and returns a brand-new *Promise
+// that settles independently of the receiver: fulfilled with the handler's
+// return value, rejected if the handler panics, or, if the receiver
+// rejects, the handler is skipped and the rejection propagates unchanged.
+// If the handler returns a *Promise, the new promise instead adopts that
+// promise's eventual state (thenable assimilation).
+func (promise *Promise) Then(fulfillment func(data interface{}) interface{}) *Promise {
+	var next = newPendingPromise()
+
+	promise.addReaction(
+		func(data interface{}) { next.settleFulfillment(fulfillment, data) },
+		func(error error) { next.reject(error) },
+	)
+
+	return next
+}
+
+// settleFulfillment runs fulfillment and resolves/rejects next accordingly,
+// assimilating the result if it is itself a *Promise.
+func (next *Promise) settleFulfillment(fulfillment func(interface{}) interface{}, data interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			next.reject(panicToError(r))
+		}
+	}()
+
+	result := fulfillment(data)
+
+	if adopted, ok := result.(*Promise); ok {
+		adopted.Then(func(data interface{}) interface{} {
+			next.resolve(data)
+			return nil
+		}).Catch(func(error error) error {
+			next.reject(error)
+			return error
+		})
+		return
+	}
+
+	next.resolve(result)
 }
 
-// Catch appends a rejection handler callback to the promise, and returns a new promise.
+// Catch registers a rejection handler and returns a brand-new *Promise that
+// settles independently of the receiver: rejected with the error the
+// handler returns (or fulfilled with nil if it returns nil), rejected if
+// the handler panics, or, if the receiver fulfills, the handler is skipped
+// and the value propagates unchanged.
 func (promise *Promise) Catch(rejection func(error error) error) *Promise {
-	promise.mutex.Lock()
-	defer promise.mutex.Unlock()
+	var next = newPendingPromise()
 
-	if promise.state == pending {
-		promise.wg.Add(1)
-		promise.catch = append(promise.catch, rejection)
-	} else if promise.state == rejected {
-		promise.error = rejection(promise.error)
+	promise.addReaction(
+		func(data interface{}) { next.resolve(data) },
+		func(error error) { next.settleRejection(rejection, error) },
+	)
+
+	return next
+}
+
+// settleRejection runs rejection and resolves/rejects next accordingly.
+func (next *Promise) settleRejection(rejection func(error) error, cause error) {
+	defer func() {
+		if r := recover(); r != nil {
+			next.reject(panicToError(r))
+		}
+	}()
+
+	if handled := rejection(cause); handled != nil {
+		next.reject(handled)
+	} else {
+		next.resolve(nil)
 	}
+}
 
-	return promise
+// Finally registers a cleanup handler that runs once the promise settles,
+// regardless of whether it fulfilled or rejected, and returns a brand-new
+// *Promise that settles with the receiver's own value or reason. It is
+// meant for side effects - closing files, releasing locks, stopping timers -
+// that must happen either way, so fn takes no arguments and its return
+// value (if any) is ignored; a panic inside fn rejects the returned promise.
+func (promise *Promise) Finally(fn func()) *Promise {
+	var next = newPendingPromise()
+
+	promise.addReaction(
+		func(data interface{}) { next.settleFinally(fn, func() { next.resolve(data) }) },
+		func(error error) { next.settleFinally(fn, func() { next.reject(error) }) },
+	)
+
+	return next
 }
 
-// Await is a blocking function that waits for all callbacks to be executed.
-func (promise *Promise) Await() {
-	promise.wg.Wait()
+// settleFinally runs fn and, unless it panics, applies outcome to propagate
+// the receiver's original value or reason onto next.
+func (next *Promise) settleFinally(fn func(), outcome func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			next.reject(panicToError(r))
+		}
+	}()
+
+	fn()
+	outcome()
+}
+
+// Await is a blocking function that waits for the promise to settle and
+// returns its result and error: (result, nil) if it fulfilled, or
+// (nil, error) if it rejected.
+func (promise *Promise) Await() (interface{}, error) {
+	<-promise.done
+
+	promise.mutex.Lock()
+	defer promise.mutex.Unlock()
+
+	if promise.state == rejected {
+		return nil, promise.error
+	}
+	return promise.result, nil
 }
 
-// AwaitAll is a blocking function that waits for a number of promises to resolve
-func AwaitAll(promises ...*Promise) {
-	for _, promise := range promises {
-		promise.Await()
+// AwaitAll is a blocking function that waits for a number of promises to
+// resolve and returns their results in order, or the first error
+// encountered.
+func AwaitAll(promises ...*Promise) ([]interface{}, error) {
+	var results = make([]interface{}, len(promises))
+
+	for i, promise := range promises {
+		result, err := promise.Await()
+		if err != nil {
+			return nil, err
+		}
+		results[i] = result
 	}
+
+	return results, nil
 }
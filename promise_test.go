@@ -17,6 +17,36 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestPromise_Await_BlocksUntilSettled(t *testing.T) {
+	var promise = New(func(resolve func(interface{}), reject func(error)) {
+		time.Sleep(50 * time.Millisecond)
+		resolve("done")
+	})
+
+	result, err := promise.Await()
+	if err != nil {
+		t.Fatal("DID NOT EXPECT AN ERROR")
+	}
+	if result.(string) != "done" {
+		t.Fatal("AWAIT SHOULD RETURN THE RESULT PASSED TO resolve(), EVEN WITH NO HANDLERS REGISTERED")
+	}
+}
+
+func TestPromise_Await_ReturnsError(t *testing.T) {
+	returnedError := errors.New("ERROR!")
+	var promise = New(func(resolve func(interface{}), reject func(error)) {
+		reject(returnedError)
+	})
+
+	result, err := promise.Await()
+	if err != returnedError {
+		t.Fatal("AWAIT SHOULD RETURN THE REASON PASSED TO reject()")
+	}
+	if result != nil {
+		t.Fatal("AWAIT SHOULD RETURN A NIL RESULT WHEN REJECTED")
+	}
+}
+
 func TestPromise_Then(t *testing.T) {
 	var promise = New(func(resolve func(interface{}), reject func(error)) {
 		resolve(1 + 1)
@@ -161,3 +191,50 @@ func TestPromise_SkipThen(t *testing.T) {
 			return err
 		})
 }
+
+func TestPromise_FinallyOnFulfilled(t *testing.T) {
+	var ranCleanup bool
+	var promise = New(func(resolve func(interface{}), reject func(error)) {
+		resolve(1 + 1)
+	})
+
+	promise.
+		Finally(func() {
+			ranCleanup = true
+		}).
+		Then(func(data interface{}) interface{} {
+			if !ranCleanup {
+				t.Fatal("CLEANUP SHOULD HAVE RUN BEFORE THEN")
+			}
+			if data.(int) != 2 {
+				t.Fatal("FINALLY SHOULD PROPAGATE THE ORIGINAL RESULT")
+			}
+			return nil
+		})
+
+	promise.Await()
+}
+
+func TestPromise_FinallyOnRejected(t *testing.T) {
+	var ranCleanup bool
+	returnedError := errors.New("ERROR!")
+	var promise = New(func(resolve func(interface{}), reject func(error)) {
+		reject(returnedError)
+	})
+
+	promise.
+		Finally(func() {
+			ranCleanup = true
+		}).
+		Catch(func(err error) error {
+			if !ranCleanup {
+				t.Fatal("CLEANUP SHOULD HAVE RUN BEFORE CATCH")
+			}
+			if err != returnedError {
+				t.Fatal("FINALLY SHOULD PROPAGATE THE ORIGINAL REASON")
+			}
+			return err
+		})
+
+	promise.Await()
+}